@@ -0,0 +1,108 @@
+package linkedql
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/cayleygraph/quad/voc"
+
+	// Blank-imported so their namespaces are registered in voc's default
+	// registry and resolve out of the box, without every caller needing to
+	// import them directly.
+	_ "github.com/cayleygraph/quad/voc/rdf"
+	_ "github.com/cayleygraph/quad/voc/rdfs"
+	_ "github.com/cayleygraph/quad/voc/schema"
+	_ "github.com/cayleygraph/quad/voc/xsd"
+)
+
+// Context holds the prefix-to-IRI mappings Unmarshal consults to expand
+// compact IRIs such as "ex:foo" into full IRIs such as
+// "http://example.org/foo". It is populated both from an inline @context
+// document and, for any prefix it does not itself define, falls back to
+// the vocabularies registered in the voc package (rdf, rdfs, xsd,
+// schema.org, and anything else an application has registered).
+type Context struct {
+	terms map[string]string
+}
+
+// NewContext returns an empty Context; prefixes registered in voc are
+// still consulted as a fallback.
+func NewContext() *Context {
+	return &Context{terms: make(map[string]string)}
+}
+
+// RegisterPrefix adds or overrides a prefix mapping on the Context.
+func (c *Context) RegisterPrefix(prefix, iri string) {
+	if c.terms == nil {
+		c.terms = make(map[string]string)
+	}
+	c.terms[prefix] = iri
+}
+
+// parseContext reads a JSON-LD 1.1 @context object into a Context. Only
+// simple term definitions ("term": "iri") and the "@id" form
+// ("term": {"@id": "iri"}) are supported.
+func parseContext(raw json.RawMessage) (*Context, error) {
+	var m map[string]interface{}
+	if err := json.Unmarshal(raw, &m); err != nil {
+		return nil, err
+	}
+	c := NewContext()
+	for term, v := range m {
+		switch v := v.(type) {
+		case string:
+			c.RegisterPrefix(term, v)
+		case map[string]interface{}:
+			if id, ok := v["@id"].(string); ok {
+				c.RegisterPrefix(term, id)
+			}
+		}
+	}
+	return c, nil
+}
+
+// merge returns a Context combining c with extra, with extra's terms
+// taking precedence. Either may be nil.
+func (c *Context) merge(extra *Context) *Context {
+	if extra == nil || len(extra.terms) == 0 {
+		return c
+	}
+	out := NewContext()
+	if c != nil {
+		for k, v := range c.terms {
+			out.terms[k] = v
+		}
+	}
+	for k, v := range extra.terms {
+		out.terms[k] = v
+	}
+	return out
+}
+
+// expandIRI expands a compact IRI ("ex:foo") into a full IRI, consulting
+// the Context's own terms before falling back to voc's registered
+// vocabularies. Values that are already absolute, or that look like blank
+// node identifiers ("_:foo"), are returned unchanged.
+func (c *Context) expandIRI(s string) string {
+	if strings.HasPrefix(s, "_:") {
+		return s
+	}
+	idx := strings.Index(s, ":")
+	if idx <= 0 {
+		return s
+	}
+	prefix, suffix := s[:idx], s[idx+1:]
+	if strings.HasPrefix(suffix, "//") {
+		// already an absolute IRI, e.g. "http://example.org/foo"
+		return s
+	}
+	if c != nil {
+		if full, ok := c.terms[prefix]; ok {
+			return full + suffix
+		}
+	}
+	if full := voc.FullIRI(s); full != s {
+		return full
+	}
+	return s
+}
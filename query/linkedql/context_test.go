@@ -0,0 +1,52 @@
+package linkedql
+
+import (
+	"testing"
+
+	"github.com/cayleygraph/quad"
+)
+
+func TestContextExpandIRI(t *testing.T) {
+	t.Run("expands via registered voc vocabularies", func(t *testing.T) {
+		got := (*Context)(nil).expandIRI("rdf:type")
+		if got == "rdf:type" {
+			t.Fatalf("expected rdf:type to expand against voc, got %q unchanged", got)
+		}
+	})
+
+	t.Run("resolves a Context's own prefixes first", func(t *testing.T) {
+		ctx := NewContext()
+		ctx.RegisterPrefix("ex", "http://example.org/")
+		if got, want := ctx.expandIRI("ex:Foo"), "http://example.org/Foo"; got != want {
+			t.Fatalf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("leaves absolute IRIs and blank nodes alone", func(t *testing.T) {
+		if got := (*Context)(nil).expandIRI("http://example.org/foo"); got != "http://example.org/foo" {
+			t.Fatalf("got %q", got)
+		}
+		if got := (*Context)(nil).expandIRI("_:b0"); got != "_:b0" {
+			t.Fatalf("got %q", got)
+		}
+	})
+}
+
+func TestUnmarshalWithInlineContext(t *testing.T) {
+	data := []byte(`{"@context":{"foo":"http://example.org/"},"@type":"ex:FuzzItem","str":"hi","val":{"@id":"foo:Thing"}}`)
+	item, err := Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	fi := item.(*fuzzItem)
+	if fi.Str != "hi" {
+		t.Fatalf("got Str %q, want %q", fi.Str, "hi")
+	}
+	id, ok := fi.Val.(quad.IRI)
+	if !ok {
+		t.Fatalf("got Val %#v, want quad.IRI", fi.Val)
+	}
+	if want := quad.IRI("http://example.org/Thing"); id != want {
+		t.Fatalf("got %q, want %q", id, want)
+	}
+}
@@ -0,0 +1,150 @@
+package linkedql
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// SyntaxError is returned by Decoder when the underlying stream contains
+// malformed JSON-LD, annotated with the line and column the error was
+// detected at.
+type SyntaxError struct {
+	Line, Column int
+	Err          error
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("linkedql: %v (line %d, column %d)", e.Err, e.Line, e.Column)
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// Decoder reads a stream of JSON-LD query documents, either newline
+// delimited or wrapped in a single top-level JSON array, and decodes each
+// one into a RegistryItem. It mirrors the usage of json.Decoder so callers
+// can process a large batch (or a live, still-arriving stream of step
+// updates) without buffering the whole payload in memory.
+type Decoder struct {
+	br      *bufio.Reader
+	dec     *json.Decoder
+	buf     bytes.Buffer // every byte read so far, in stream order, for position()
+	arr     bool
+	started bool
+}
+
+// NewDecoder returns a Decoder that reads from r.
+func NewDecoder(r io.Reader) *Decoder {
+	d := &Decoder{}
+	tr := io.TeeReader(r, &d.buf)
+	d.br = bufio.NewReader(tr)
+	d.dec = json.NewDecoder(d.br)
+	return d
+}
+
+// init detects, on the first call, whether the stream is a JSON array of
+// documents or a sequence of newline-delimited documents. It peeks the
+// first non-whitespace byte rather than reading a json.Decoder Token,
+// since Token would consume the opening '{' of a bare document just as
+// readily as the opening '[' of an array, making the two cases
+// indistinguishable. Only once '[' is confirmed does it consume that
+// token, so array mode can rely on json.Decoder.More/Decode for the
+// elements and stream mode never loses a document's opening brace.
+func (d *Decoder) init() error {
+	if d.started {
+		return nil
+	}
+	d.started = true
+	for {
+		b, err := d.br.Peek(1)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			d.br.ReadByte()
+			continue
+		}
+		if b[0] == '[' {
+			d.arr = true
+			if _, err := d.dec.Token(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// More reports whether there is another document left to Decode.
+func (d *Decoder) More() bool {
+	if err := d.init(); err != nil {
+		return false
+	}
+	return d.dec.More()
+}
+
+// Decode reads the next JSON-LD document from the stream and unmarshals it
+// into a RegistryItem, recursing through the registry for any nested
+// @type-tagged items exactly as Unmarshal does.
+func (d *Decoder) Decode() (RegistryItem, error) {
+	if err := d.init(); err != nil {
+		return nil, d.wrapErr(err)
+	}
+	if !d.dec.More() {
+		return nil, io.EOF
+	}
+	var raw json.RawMessage
+	if err := d.dec.Decode(&raw); err != nil {
+		return nil, d.wrapErr(err)
+	}
+	item, err := Unmarshal(raw)
+	if err != nil {
+		return nil, d.wrapErr(err)
+	}
+	return item, nil
+}
+
+// wrapErr annotates err with the line/column it occurred at. The offset
+// is taken from the error itself when encoding/json supplies one (for
+// malformed JSON), falling back to the decoder's current input offset
+// (for errors raised after decoding, e.g. from Unmarshal), and is then
+// translated to a line/column by scanning the bytes read so far — not by
+// counting bytes as they come off the underlying io.Reader, which runs
+// far ahead of what json.Decoder has actually parsed once bufio.Reader's
+// read-ahead buffering is in play.
+func (d *Decoder) wrapErr(err error) error {
+	if err == nil || err == io.EOF {
+		return err
+	}
+	offset := d.dec.InputOffset()
+	if se, ok := err.(*json.SyntaxError); ok {
+		offset = se.Offset
+	}
+	line, col := d.position(offset)
+	return &SyntaxError{Line: line, Column: col, Err: err}
+}
+
+// position translates a byte offset into the stream into a 1-based
+// line/column pair, by scanning the bytes the Decoder has read so far.
+func (d *Decoder) position(offset int64) (line, col int) {
+	data := d.buf.Bytes()
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	line = 1
+	lastNL := -1
+	for i := int64(0); i < offset; i++ {
+		if data[i] == '\n' {
+			line++
+			lastNL = int(i)
+		}
+	}
+	return line, int(offset) - lastNL
+}
@@ -0,0 +1,53 @@
+package linkedql
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDecoderNewlineDelimited(t *testing.T) {
+	in := `{"@type":"ex:FuzzItem","str":"a"}
+{"@type":"ex:FuzzItem","str":"b"}
+`
+	dec := NewDecoder(strings.NewReader(in))
+	var got []string
+	for dec.More() {
+		item, err := dec.Decode()
+		if err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		got = append(got, item.(*fuzzItem).Str)
+	}
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Fatalf("got %v, want [a b]", got)
+	}
+}
+
+func TestDecoderArray(t *testing.T) {
+	in := `[{"@type":"ex:FuzzItem","str":"a"},{"@type":"ex:FuzzItem","str":"b"}]`
+	dec := NewDecoder(strings.NewReader(in))
+	var got []string
+	for dec.More() {
+		item, err := dec.Decode()
+		if err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		got = append(got, item.(*fuzzItem).Str)
+	}
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Fatalf("got %v, want [a b]", got)
+	}
+}
+
+func TestDecoderSyntaxErrorPosition(t *testing.T) {
+	in := "{\"@type\":\"ex:FuzzItem\",\n\"str\":BAD}"
+	dec := NewDecoder(strings.NewReader(in))
+	_, err := dec.Decode()
+	se, ok := err.(*SyntaxError)
+	if !ok {
+		t.Fatalf("expected *SyntaxError, got %v (%T)", err, err)
+	}
+	if se.Line != 2 {
+		t.Fatalf("got line %d, want 2", se.Line)
+	}
+}
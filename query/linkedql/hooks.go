@@ -0,0 +1,44 @@
+package linkedql
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/cayleygraph/quad"
+)
+
+var (
+	valueParsers  = make(map[string]func(json.RawMessage) (quad.Value, error))
+	valueEncoders = make(map[reflect.Type]func(quad.Value) (interface{}, error))
+)
+
+// RegisterValueParser teaches parseValue about an additional JSON-LD
+// literal datatype: whenever it encounters a `{"@value": ..., "@type":
+// typeIRI}` node whose (expanded) @type matches typeIRI, it hands the raw
+// node to fn instead of falling back to the generic TypedString path.
+// This lets downstream modules extend the value grammar (xsd:dateTime,
+// xsd:decimal, geo:wktLiteral, ...) without patching this package.
+//
+// RegisterValueParser panics if typeIRI is already registered.
+func RegisterValueParser(typeIRI string, fn func(json.RawMessage) (quad.Value, error)) {
+	if _, ok := valueParsers[typeIRI]; ok {
+		panic(fmt.Sprintf("linkedql: a value parser for %q was already registered", typeIRI))
+	}
+	valueParsers[typeIRI] = fn
+}
+
+// RegisterValueEncoder is the symmetric hook for Marshal: it teaches
+// marshalValue how to serialize a custom quad.Value implementation, so
+// values round-tripped through RegisterValueParser encode back to the
+// same JSON-LD form. typ is a zero value of the concrete type to encode
+// for, e.g. RegisterValueEncoder(geo.WKTLiteral{}, ...).
+//
+// RegisterValueEncoder panics if the type already has an encoder.
+func RegisterValueEncoder(typ quad.Value, fn func(quad.Value) (interface{}, error)) {
+	tp := reflect.TypeOf(typ)
+	if _, ok := valueEncoders[tp]; ok {
+		panic(fmt.Sprintf("linkedql: a value encoder for %s was already registered", tp))
+	}
+	valueEncoders[tp] = fn
+}
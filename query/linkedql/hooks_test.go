@@ -0,0 +1,73 @@
+package linkedql
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cayleygraph/quad"
+)
+
+const wktTypeIRI = "http://example.org/geo#wktLiteral"
+
+// wktLiteral wraps a quad.String purely so it has a distinct concrete type
+// to register hooks against; it satisfies quad.Value by forwarding to the
+// wrapped value.
+type wktLiteral struct {
+	Text quad.String
+}
+
+func (l wktLiteral) String() string      { return l.Text.String() }
+func (l wktLiteral) Native() interface{} { return l.Text.Native() }
+
+func init() {
+	RegisterValueParser(wktTypeIRI, func(raw json.RawMessage) (quad.Value, error) {
+		var node struct {
+			Value string `json:"@value"`
+		}
+		if err := json.Unmarshal(raw, &node); err != nil {
+			return nil, err
+		}
+		return wktLiteral{quad.String(node.Value)}, nil
+	})
+	RegisterValueEncoder(wktLiteral{}, func(v quad.Value) (interface{}, error) {
+		lit := v.(wktLiteral)
+		return map[string]interface{}{"@value": string(lit.Text), "@type": wktTypeIRI}, nil
+	})
+}
+
+func TestValueParserEncoderHooksRoundTrip(t *testing.T) {
+	data := []byte(`{"@type":"ex:FuzzItem","str":"hi","val":{"@value":"POINT(1 1)","@type":"` + wktTypeIRI + `"}}`)
+	item, err := Unmarshal(data)
+	if err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	lit, ok := item.(*fuzzItem).Val.(wktLiteral)
+	if !ok {
+		t.Fatalf("got Val %#v, want wktLiteral", item.(*fuzzItem).Val)
+	}
+	if string(lit.Text) != "POINT(1 1)" {
+		t.Fatalf("got %q, want %q", lit.Text, "POINT(1 1)")
+	}
+
+	out, err := Marshal(item)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+	val, ok := doc["val"].(map[string]interface{})
+	if !ok || val["@type"] != wktTypeIRI {
+		t.Fatalf("got val %#v, want @type %q", doc["val"], wktTypeIRI)
+	}
+}
+
+func TestRegisterValueParserPanicsOnDuplicate(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic registering a duplicate type IRI")
+		}
+	}()
+	RegisterValueParser(wktTypeIRI, func(json.RawMessage) (quad.Value, error) { return nil, nil })
+}
@@ -0,0 +1,98 @@
+// Package jsonld converts generic, string-keyed documents decoded from a
+// friendlier front-end syntax (YAML, TOML, ...) into canonical JSON-LD
+// shaped maps, so every linkedql front-end can delegate to the shared
+// registry-driven decoder instead of duplicating its schema logic.
+package jsonld
+
+import "fmt"
+
+// Canonicalize walks a value decoded from YAML/TOML/etc. (maps, slices and
+// scalars) and rewrites it into the JSON-LD keyword shape
+// linkedql.Unmarshal expects, suitable for json.Marshal followed by
+// linkedql.Unmarshal.
+//
+// Renaming only happens where a JSON-LD keyword is structurally expected,
+// not as a blind string substitution everywhere: "type" always names the
+// @type discriminator every registered step carries, so it is renamed at
+// every map. "id"/"value"/"language" are only renamed inside a map that
+// is itself shaped like a JSON-LD value literal (exactly {id}, {value,
+// type} or {value, language}) — never at a step's own field level, where
+// a field legitimately named e.g. "value" must reach the registry
+// unchanged. "context" is only renamed at the document root, the one
+// place an inline @context is read from.
+//
+// This heuristic cannot perfectly disambiguate a two-field nested step
+// whose only fields happen to be named exactly "value"+"type" (or
+// "value"+"language") from an actual value literal; registered steps
+// should avoid that exact shape for a field holding a nested item.
+func Canonicalize(v interface{}) interface{} {
+	return canonicalizeNode(v, true)
+}
+
+func canonicalizeNode(v interface{}, isRoot bool) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		return canonicalizeMap(toStringMap(v), isRoot)
+	case map[string]interface{}:
+		return canonicalizeMap(v, isRoot)
+	case []interface{}:
+		arr := make([]interface{}, len(v))
+		for i, e := range v {
+			arr[i] = canonicalizeNode(e, false)
+		}
+		return arr
+	case []map[string]interface{}:
+		arr := make([]interface{}, len(v))
+		for i, e := range v {
+			arr[i] = canonicalizeMap(e, false)
+		}
+		return arr
+	default:
+		return v
+	}
+}
+
+func canonicalizeMap(m map[string]interface{}, isRoot bool) map[string]interface{} {
+	literal := !isRoot && isValueLiteral(m)
+	out := make(map[string]interface{}, len(m))
+	for k, val := range m {
+		nk := k
+		switch {
+		case k == "type":
+			nk = "@type"
+		case k == "context" && isRoot:
+			nk = "@context"
+		case literal && (k == "id" || k == "value" || k == "language"):
+			nk = "@" + k
+		}
+		out[nk] = canonicalizeNode(val, false)
+	}
+	return out
+}
+
+// isValueLiteral reports whether m's keys exactly match one of the JSON-LD
+// value literal shapes: a bare reference ({id}), a typed literal ({value,
+// type}), or a language-tagged literal ({value, language}).
+func isValueLiteral(m map[string]interface{}) bool {
+	switch len(m) {
+	case 1:
+		_, hasID := m["id"]
+		return hasID
+	case 2:
+		if _, hasValue := m["value"]; !hasValue {
+			return false
+		}
+		_, hasType := m["type"]
+		_, hasLang := m["language"]
+		return hasType || hasLang
+	}
+	return false
+}
+
+func toStringMap(m map[interface{}]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[fmt.Sprint(k)] = v
+	}
+	return out
+}
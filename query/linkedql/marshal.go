@@ -0,0 +1,159 @@
+package linkedql
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/cayleygraph/quad"
+)
+
+// Marshal inverts Unmarshal: it serializes a RegistryItem back into its
+// JSON-LD form, emitting the @type IRI the item was registered under and
+// honoring the same `json` struct tags Unmarshal reads.
+func Marshal(item RegistryItem) ([]byte, error) {
+	v, err := marshalItem(item)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(v)
+}
+
+func marshalItem(item RegistryItem) (map[string]interface{}, error) {
+	if item == nil {
+		return nil, fmt.Errorf("linkedql: cannot marshal a nil item")
+	}
+	rv := reflect.ValueOf(item)
+	if rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil, fmt.Errorf("linkedql: cannot marshal a nil item")
+		}
+		rv = rv.Elem()
+	}
+	tp := rv.Type()
+	name, ok := nameByType[tp]
+	if !ok {
+		return nil, fmt.Errorf("linkedql: type %s is not registered", tp)
+	}
+	m := map[string]interface{}{"@type": name}
+	for i := 0; i < tp.NumField(); i++ {
+		f := tp.Field(i)
+		tag := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+		if tag == "-" {
+			continue
+		}
+		fname := f.Name
+		if tag != "" {
+			fname = tag
+		}
+		fv := rv.Field(i)
+		switch f.Type {
+		case quadValue:
+			if fv.IsNil() {
+				continue
+			}
+			val, err := marshalValue(fv.Interface().(quad.Value))
+			if err != nil {
+				return nil, err
+			}
+			m[fname] = val
+			continue
+		case quadSliceValue:
+			vals, _ := fv.Interface().([]quad.Value)
+			if vals == nil {
+				continue
+			}
+			arr := make([]interface{}, len(vals))
+			for i, val := range vals {
+				enc, err := marshalValue(val)
+				if err != nil {
+					return nil, err
+				}
+				arr[i] = enc
+			}
+			m[fname] = arr
+			continue
+		}
+		switch f.Type.Kind() {
+		case reflect.Interface:
+			if fv.IsNil() {
+				continue
+			}
+			sub, err := marshalItem(fv.Interface().(RegistryItem))
+			if err != nil {
+				return nil, err
+			}
+			m[fname] = sub
+		case reflect.Slice:
+			el := f.Type.Elem()
+			if el.Kind() != reflect.Interface {
+				m[fname] = fv.Interface()
+			} else {
+				if fv.IsNil() {
+					continue
+				}
+				arr := make([]interface{}, fv.Len())
+				for i := 0; i < fv.Len(); i++ {
+					sub, err := marshalItem(fv.Index(i).Interface().(RegistryItem))
+					if err != nil {
+						return nil, err
+					}
+					arr[i] = sub
+				}
+				m[fname] = arr
+			}
+		default:
+			m[fname] = fv.Interface()
+		}
+	}
+	return m, nil
+}
+
+// marshalValue serializes a quad.Value into its JSON-LD representation:
+// @id for IRIs and BNodes, @value/@type for TypedString, @value/@language
+// for LangString, and a bare string for quad.String.
+func marshalValue(v quad.Value) (interface{}, error) {
+	switch v := v.(type) {
+	case quad.IRI:
+		return map[string]interface{}{"@id": string(v)}, nil
+	case quad.BNode:
+		return map[string]interface{}{"@id": "_:" + string(v)}, nil
+	case quad.TypedString:
+		return map[string]interface{}{"@value": string(v.Value), "@type": string(v.Type)}, nil
+	case quad.LangString:
+		return map[string]interface{}{"@value": string(v.Value), "@language": v.Lang}, nil
+	case quad.String:
+		return string(v), nil
+	default:
+		if fn, ok := valueEncoders[reflect.TypeOf(v)]; ok {
+			return fn(v)
+		}
+		return nil, fmt.Errorf("linkedql: cannot marshal quad value of type %T", v)
+	}
+}
+
+// Encoder writes a stream of RegistryItems to an output stream, one
+// JSON-LD document per Encode call, mirroring json.Encoder.
+type Encoder struct {
+	w io.Writer
+}
+
+// NewEncoder returns a new Encoder that writes to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// Encode marshals item and writes it to the stream, followed by a newline.
+func (e *Encoder) Encode(item RegistryItem) error {
+	data, err := Marshal(item)
+	if err != nil {
+		return err
+	}
+	if _, err := e.w.Write(data); err != nil {
+		return err
+	}
+	_, err = e.w.Write([]byte("\n"))
+	return err
+}
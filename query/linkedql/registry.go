@@ -34,16 +34,41 @@ func Register(typ RegistryItem) {
 var quadValue = reflect.TypeOf((*quad.Value)(nil)).Elem()
 var quadSliceValue = reflect.TypeOf(([]quad.Value)(nil))
 
-// Unmarshal attempts to unmarshal an Item or returns error
+// Unmarshal attempts to unmarshal an Item or returns error. Compact IRIs
+// are expanded against the document's root-level @context, if it has one,
+// and the vocabularies registered in voc (rdf, rdfs, xsd, schema.org,
+// ...); use UnmarshalWithContext to also resolve prefixes supplied by the
+// caller.
 func Unmarshal(data []byte) (RegistryItem, error) {
+	return UnmarshalWithContext(nil, data)
+}
+
+// UnmarshalWithContext behaves like Unmarshal, additionally resolving
+// compact IRIs against ctx. If the document has a root-level @context
+// object, it is parsed and merged into ctx (taking precedence over it)
+// before the document is decoded.
+func UnmarshalWithContext(ctx *Context, data []byte) (RegistryItem, error) {
 	var m map[string]json.RawMessage
 	if err := json.Unmarshal(data, &m); err != nil {
 		return nil, err
 	}
+	if raw, ok := m["@context"]; ok {
+		inline, err := parseContext(raw)
+		if err != nil {
+			return nil, err
+		}
+		ctx = ctx.merge(inline)
+		delete(m, "@context")
+	}
+	return unmarshalFields(m, ctx)
+}
+
+func unmarshalFields(m map[string]json.RawMessage, ctx *Context) (RegistryItem, error) {
 	var typ string
 	if err := json.Unmarshal(m["@type"], &typ); err != nil {
 		return nil, err
 	}
+	typ = ctx.expandIRI(typ)
 	delete(m, "@type")
 	tp, ok := typeByName[typ]
 	if !ok {
@@ -71,21 +96,24 @@ func Unmarshal(data []byte) (RegistryItem, error) {
 			if err != nil {
 				return nil, err
 			}
-			value, err := parseValue(v)
+			value, err := parseValue(a, v, ctx)
 			if err != nil {
 				return nil, err
 			}
 			fv.Set(reflect.ValueOf(value))
 			continue
 		case quadSliceValue:
-			var a []interface{}
-			err := json.Unmarshal(v, &a)
-			if err != nil {
+			var raws []json.RawMessage
+			if err := json.Unmarshal(v, &raws); err != nil {
 				return nil, err
 			}
 			var values []quad.Value
-			for _, item := range a {
-				value, err := parseValue(item)
+			for _, raw := range raws {
+				var a interface{}
+				if err := json.Unmarshal(raw, &a); err != nil {
+					return nil, err
+				}
+				value, err := parseValue(a, raw, ctx)
 				if err != nil {
 					return nil, err
 				}
@@ -96,7 +124,7 @@ func Unmarshal(data []byte) (RegistryItem, error) {
 		}
 		switch f.Type.Kind() {
 		case reflect.Interface:
-			s, err := Unmarshal(v)
+			s, err := unmarshalRaw(v, ctx)
 			if err != nil {
 				return nil, err
 			}
@@ -116,7 +144,7 @@ func Unmarshal(data []byte) (RegistryItem, error) {
 				if arr != nil {
 					va := reflect.MakeSlice(f.Type, len(arr), len(arr))
 					for i, v := range arr {
-						s, err := Unmarshal(v)
+						s, err := unmarshalRaw(v, ctx)
 						if err != nil {
 							return nil, err
 						}
@@ -135,12 +163,22 @@ func Unmarshal(data []byte) (RegistryItem, error) {
 	return item.Addr().Interface().(RegistryItem), nil
 }
 
+// unmarshalRaw decodes a nested JSON-LD object, reusing ctx for IRI
+// resolution rather than re-reading a root-level @context.
+func unmarshalRaw(data json.RawMessage, ctx *Context) (RegistryItem, error) {
+	var m map[string]json.RawMessage
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return unmarshalFields(m, ctx)
+}
+
 const xsd = "http://www.w3.org/2001/XMLSchema#"
 const xsdInt = xsd + "integer"
 const xsdFloat = xsd + "float"
 const xsdBool = xsd + "boolean"
 
-func parseValue(a interface{}) (quad.Value, error) {
+func parseValue(a interface{}, raw json.RawMessage, ctx *Context) (quad.Value, error) {
 	switch a := a.(type) {
 	case string:
 		return quad.String(a), nil
@@ -150,21 +188,34 @@ func parseValue(a interface{}) (quad.Value, error) {
 		return quad.TypedString{Value: quad.String(fmt.Sprintf("%f", a)), Type: quad.IRI(xsdFloat)}, nil
 	case bool:
 		return quad.TypedString{Value: quad.String(fmt.Sprintf("%t", a)), Type: quad.IRI(xsdBool)}, nil
+	case json.Number:
+		if _, err := a.Int64(); err == nil {
+			return quad.TypedString{Value: quad.String(a.String()), Type: quad.IRI(xsdInt)}, nil
+		}
+		f, err := a.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return quad.TypedString{Value: quad.String(fmt.Sprintf("%f", f)), Type: quad.IRI(xsdFloat)}, nil
 	case map[string]interface{}:
 		id, ok := a["@id"].(string)
 		if ok {
 			if strings.HasPrefix(id, "_:") {
 				return quad.BNode(id[2:]), nil
 			}
-			return quad.IRI(id), nil
+			return quad.IRI(ctx.expandIRI(id)), nil
 		}
 		value, ok := a["@value"].(string)
 		if ok {
 			if language, ok := a["@language"].(string); ok {
-				return quad.LangString{Value: quad.String("value"), Lang: language}, nil
+				return quad.LangString{Value: quad.String(value), Lang: language}, nil
 			}
 			if _type, ok := a["@type"].(string); ok {
-				return quad.TypedString{Value: quad.String(value), Type: quad.IRI(_type)}, nil
+				typeIRI := ctx.expandIRI(_type)
+				if fn, ok := valueParsers[typeIRI]; ok {
+					return fn(raw)
+				}
+				return quad.TypedString{Value: quad.String(value), Type: quad.IRI(typeIRI)}, nil
 			}
 		}
 	}
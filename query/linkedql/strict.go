@@ -0,0 +1,279 @@
+package linkedql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cayleygraph/quad"
+)
+
+// ValidationError reports a structural problem found by UnmarshalStrict:
+// an unknown field, a missing required field, or a malformed value, along
+// with the offending type, the field path and the byte offset into the
+// original input at which the problem was found. For a malformed or
+// unknown field, Pos is the offset right after that field's value; for a
+// missing field it's the offset of the end of the enclosing object, since
+// there's no value to point at.
+type ValidationError struct {
+	Type  string
+	Field string
+	Msg   string
+	Pos   int64
+}
+
+func (e *ValidationError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("linkedql: %s (type %q, offset %d)", e.Msg, e.Type, e.Pos)
+	}
+	return fmt.Sprintf("linkedql: %s (type %q, field %q, offset %d)", e.Msg, e.Type, e.Field, e.Pos)
+}
+
+// UnmarshalStrict behaves like Unmarshal but rejects documents that are
+// not exactly what the registered type expects: unknown JSON keys and
+// missing required fields are reported as a *ValidationError instead of
+// being silently ignored. JSON numbers are decoded via json.Number so a
+// quad.Value field coerces them to xsd:integer or xsd:float based on
+// their actual shape, rather than parseValue's regular path, which always
+// produces xsd:float because encoding/json decodes every bare number as
+// a float64.
+//
+// A struct field is considered required unless it is tagged
+// `json:",omitempty"` or has a pointer, interface, slice or map type.
+//
+// The top-level object's fields are read off one streaming json.Decoder;
+// nested items and array elements are decoded off their own sub-decoders,
+// whose offsets are translated back into the original input. A
+// *ValidationError's Pos is the offset recorded at the moment that
+// specific field (or, for a nested item, that specific sub-document) was
+// decoded — not a single snapshot taken once for the whole call — so
+// errors from different fields or nesting depths point at different,
+// meaningful positions in the input.
+func UnmarshalStrict(data []byte) (RegistryItem, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return decodeItemStrict(dec, nil, 0)
+}
+
+// decodeItemStrict reads one JSON-LD object off dec. base is the absolute
+// offset, within the original input, at which dec's own underlying reader
+// starts — 0 for the top-level call, and the start of the relevant raw
+// slice for a nested object decoded off its own sub-decoder.
+func decodeItemStrict(dec *json.Decoder, ctx *Context, base int64) (RegistryItem, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return nil, &ValidationError{Msg: "expected a JSON object", Pos: base + dec.InputOffset()}
+	}
+	fields := make(map[string]json.RawMessage)
+	fieldPos := make(map[string]int64)
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := keyTok.(string)
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, err
+		}
+		fields[key] = raw
+		fieldPos[key] = base + dec.InputOffset()
+	}
+	if _, err := dec.Token(); err != nil { // consume closing '}'
+		return nil, err
+	}
+	objPos := base + dec.InputOffset()
+
+	if raw, ok := fields["@context"]; ok {
+		inline, err := parseContext(raw)
+		if err != nil {
+			return nil, err
+		}
+		ctx = ctx.merge(inline)
+		delete(fields, "@context")
+	}
+	rawType, ok := fields["@type"]
+	if !ok {
+		return nil, &ValidationError{Msg: "missing or malformed @type", Pos: objPos}
+	}
+	var typ string
+	if err := json.Unmarshal(rawType, &typ); err != nil {
+		return nil, &ValidationError{Msg: "missing or malformed @type", Pos: fieldPos["@type"]}
+	}
+	typ = ctx.expandIRI(typ)
+	delete(fields, "@type")
+	tp, ok := typeByName[typ]
+	if !ok {
+		return nil, &ValidationError{Type: typ, Msg: "unsupported item", Pos: objPos}
+	}
+
+	item := reflect.New(tp).Elem()
+	seen := make(map[string]bool, tp.NumField())
+	for i := 0; i < tp.NumField(); i++ {
+		f := tp.Field(i)
+		name := f.Name
+		tag := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+		if tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+		seen[name] = true
+		raw, ok := fields[name]
+		if !ok {
+			if isRequiredField(f) {
+				return nil, &ValidationError{Type: typ, Field: name, Msg: "missing required field", Pos: objPos}
+			}
+			continue
+		}
+		pos := fieldPos[name]
+		if err := unmarshalFieldStrict(item.Field(i), f.Type, raw, ctx, pos); err != nil {
+			if ve, ok := err.(*ValidationError); ok {
+				if ve.Type == "" {
+					ve.Type = typ
+				}
+				if ve.Field == "" {
+					ve.Field = name
+				}
+				return nil, ve
+			}
+			return nil, &ValidationError{Type: typ, Field: name, Msg: err.Error(), Pos: pos}
+		}
+	}
+	for name := range fields {
+		if !seen[name] {
+			return nil, &ValidationError{Type: typ, Field: name, Msg: "unknown field", Pos: fieldPos[name]}
+		}
+	}
+	return item.Addr().Interface().(RegistryItem), nil
+}
+
+// unmarshalFieldStrict decodes a single field's raw value into fv. pos is
+// the absolute offset of the end of raw within the original input, so
+// recursing into a nested object can compute that object's own absolute
+// base offset as pos - len(raw).
+func unmarshalFieldStrict(fv reflect.Value, ft reflect.Type, raw json.RawMessage, ctx *Context, pos int64) error {
+	switch ft {
+	case quadValue:
+		a, err := decodeNumberAware(raw)
+		if err != nil {
+			return err
+		}
+		value, err := parseValue(a, raw, ctx)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(value))
+		return nil
+	case quadSliceValue:
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		if _, err := dec.Token(); err != nil { // '['
+			return err
+		}
+		var values []quad.Value
+		for dec.More() {
+			var elem json.RawMessage
+			if err := dec.Decode(&elem); err != nil {
+				return err
+			}
+			a, err := decodeNumberAware(elem)
+			if err != nil {
+				return err
+			}
+			value, err := parseValue(a, elem, ctx)
+			if err != nil {
+				return err
+			}
+			values = append(values, value)
+		}
+		fv.Set(reflect.ValueOf(values))
+		return nil
+	}
+	switch ft.Kind() {
+	case reflect.Interface:
+		base := pos - int64(len(raw))
+		sub := json.NewDecoder(bytes.NewReader(raw))
+		sub.UseNumber()
+		item, err := decodeItemStrict(sub, ctx, base)
+		if err != nil {
+			return err
+		}
+		fv.Set(reflect.ValueOf(item))
+		return nil
+	case reflect.Slice:
+		el := ft.Elem()
+		if el.Kind() != reflect.Interface {
+			return json.Unmarshal(raw, fv.Addr().Interface())
+		}
+		base := pos - int64(len(raw))
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		if _, err := dec.Token(); err != nil { // '['
+			return err
+		}
+		var elems []reflect.Value
+		for dec.More() {
+			var elemRaw json.RawMessage
+			if err := dec.Decode(&elemRaw); err != nil {
+				return err
+			}
+			elemBase := base + dec.InputOffset() - int64(len(elemRaw))
+			sub := json.NewDecoder(bytes.NewReader(elemRaw))
+			sub.UseNumber()
+			item, err := decodeItemStrict(sub, ctx, elemBase)
+			if err != nil {
+				return err
+			}
+			elems = append(elems, reflect.ValueOf(item))
+		}
+		if elems != nil {
+			va := reflect.MakeSlice(ft, len(elems), len(elems))
+			for i, e := range elems {
+				va.Index(i).Set(e)
+			}
+			fv.Set(va)
+		}
+		return nil
+	default:
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		return dec.Decode(fv.Addr().Interface())
+	}
+}
+
+// decodeNumberAware unmarshals a raw JSON value the same way
+// json.Decoder.UseNumber does, so a bare number surfaces as json.Number
+// instead of always becoming a float64.
+func decodeNumberAware(v json.RawMessage) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(v))
+	dec.UseNumber()
+	var a interface{}
+	if err := dec.Decode(&a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// isRequiredField reports whether a struct field must be present in the
+// input for UnmarshalStrict to accept it.
+func isRequiredField(f reflect.StructField) bool {
+	tag := f.Tag.Get("json")
+	if tag != "" {
+		for _, opt := range strings.Split(tag, ",")[1:] {
+			if opt == "omitempty" {
+				return false
+			}
+		}
+	}
+	switch f.Type.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map:
+		return false
+	}
+	return true
+}
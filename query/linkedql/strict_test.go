@@ -0,0 +1,59 @@
+package linkedql
+
+import (
+	"testing"
+
+	"github.com/cayleygraph/quad"
+)
+
+func TestUnmarshalStrictRejectsUnknownField(t *testing.T) {
+	data := []byte(`{"@type":"ex:FuzzItem","str":"hi","bogus":1}`)
+	_, err := UnmarshalStrict(data)
+	ve, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("expected *ValidationError, got %v (%T)", err, err)
+	}
+	if ve.Field != "bogus" {
+		t.Fatalf("got field %q, want %q", ve.Field, "bogus")
+	}
+}
+
+func TestUnmarshalStrictRejectsMissingRequiredField(t *testing.T) {
+	data := []byte(`{"@type":"ex:FuzzItem"}`)
+	_, err := UnmarshalStrict(data)
+	ve, ok := err.(*ValidationError)
+	if !ok {
+		t.Fatalf("expected *ValidationError, got %v (%T)", err, err)
+	}
+	if ve.Field != "str" {
+		t.Fatalf("got field %q, want %q", ve.Field, "str")
+	}
+}
+
+func TestUnmarshalStrictKeepsIntegerPrecision(t *testing.T) {
+	data := []byte(`{"@type":"ex:FuzzItem","str":"hi","val":42}`)
+	item, err := UnmarshalStrict(data)
+	if err != nil {
+		t.Fatalf("UnmarshalStrict: %v", err)
+	}
+	got, ok := item.(*fuzzItem).Val.(quad.TypedString)
+	if !ok {
+		t.Fatalf("got Val %#v, want quad.TypedString", item.(*fuzzItem).Val)
+	}
+	if got.Value != "42" || got.Type != quad.IRI(xsdInt) {
+		t.Fatalf("got %#v, want {42 xsd:integer}", got)
+	}
+}
+
+func TestUnmarshalStrictReportsDistinctPositions(t *testing.T) {
+	_, err1 := UnmarshalStrict([]byte(`{"@type":"ex:FuzzItem"}`))
+	_, err2 := UnmarshalStrict([]byte(`{"@type":"ex:FuzzItem","vals":[]}`))
+	ve1, ok1 := err1.(*ValidationError)
+	ve2, ok2 := err2.(*ValidationError)
+	if !ok1 || !ok2 {
+		t.Fatalf("expected *ValidationError, got %v / %v", err1, err2)
+	}
+	if ve1.Pos == ve2.Pos {
+		t.Fatalf("expected different positions for differently-sized documents, both got %d", ve1.Pos)
+	}
+}
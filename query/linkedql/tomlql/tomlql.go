@@ -0,0 +1,28 @@
+// Package tomlql lets LinkedQL pipelines be authored as TOML instead of
+// JSON-LD. It converts the TOML document to a canonical JSON-LD shaped
+// map (mapping friendly keys like `type =` and `id =` to `@type`/`@id`)
+// and delegates to the registry-driven linkedql.Unmarshal, so the query
+// schema only has to live in one place.
+package tomlql
+
+import (
+	"encoding/json"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/cayleygraph/cayley/query/linkedql"
+	"github.com/cayleygraph/cayley/query/linkedql/internal/jsonld"
+)
+
+// Unmarshal decodes a TOML document into a RegistryItem.
+func Unmarshal(data []byte) (linkedql.RegistryItem, error) {
+	var doc map[string]interface{}
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	out, err := json.Marshal(jsonld.Canonicalize(doc))
+	if err != nil {
+		return nil, err
+	}
+	return linkedql.Unmarshal(out)
+}
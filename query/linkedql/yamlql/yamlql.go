@@ -0,0 +1,56 @@
+// Package yamlql lets LinkedQL pipelines be authored as YAML instead of
+// JSON-LD. It converts the YAML document to a canonical JSON-LD shaped
+// map (mapping friendly keys like `type:` and `id:` to `@type`/`@id`) and
+// delegates to the registry-driven linkedql.Unmarshal, so the query
+// schema only has to live in one place.
+package yamlql
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/cayleygraph/cayley/query/linkedql"
+	"github.com/cayleygraph/cayley/query/linkedql/internal/jsonld"
+)
+
+// Unmarshal decodes a single YAML document into a RegistryItem.
+func Unmarshal(data []byte) (linkedql.RegistryItem, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return toItem(doc)
+}
+
+// UnmarshalAll decodes a multi-document YAML stream (documents separated
+// by "---") into a RegistryItem per document.
+func UnmarshalAll(data []byte) ([]linkedql.RegistryItem, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	var items []linkedql.RegistryItem
+	for {
+		var doc interface{}
+		if err := dec.Decode(&doc); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		item, err := toItem(doc)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func toItem(doc interface{}) (linkedql.RegistryItem, error) {
+	data, err := json.Marshal(jsonld.Canonicalize(doc))
+	if err != nil {
+		return nil, err
+	}
+	return linkedql.Unmarshal(data)
+}